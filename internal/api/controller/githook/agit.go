@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/internal/api/auth"
+	"github.com/harness/gitness/internal/api/usererror"
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// refPrefixAGit is the magic namespace recognized for AGit-style pushes,
+	// e.g. `git push origin HEAD:refs/for/main -o topic=my-feature` or
+	// `git push origin HEAD:refs/for/main/my-feature`.
+	refPrefixAGit = "refs/for/"
+
+	// refFormatPullReqHead is the internal ref an AGit push is landed on.
+	// It is never created as a real branch on the repository.
+	refFormatPullReqHead = "refs/pull/%d/head"
+
+	// agitSourceBranchFormat encodes the pushing principal and topic into a
+	// synthetic source branch name so repeat AGit pushes without push access
+	// to create a branch can still be matched back to an existing PullReq.
+	agitSourceBranchFormat = "agit/%d/%s"
+
+	pushOptionTopic       = "topic"
+	pushOptionTitle       = "title"
+	pushOptionDescription = "description"
+	pushOptionForce       = "force-push"
+
+	defaultAGitTopic = "patch"
+)
+
+// AGitPushOptions are the `-o key=value` push options a client can pass
+// alongside an AGit push.
+type AGitPushOptions struct {
+	Topic       string
+	Title       string
+	Description string
+	ForcePush   bool
+}
+
+// ParseAGitPushOptions extracts the push options gitness understands from the
+// raw `-o` values git forwards to the pre-receive hook. Unknown options are
+// ignored so future options can be added without breaking older clients.
+func ParseAGitPushOptions(raw []string) AGitPushOptions {
+	out := AGitPushOptions{}
+	for _, o := range raw {
+		key, value, _ := strings.Cut(o, "=")
+		switch key {
+		case pushOptionTopic:
+			out.Topic = value
+		case pushOptionTitle:
+			out.Title = value
+		case pushOptionDescription:
+			out.Description = value
+		case pushOptionForce:
+			out.ForcePush = true
+		}
+	}
+	return out
+}
+
+// ParseAGitRef strips the `refs/for/` magic namespace off ref, returning the
+// remainder (still possibly containing slashes) that identifies the target
+// branch and, optionally, a topic. ok is false if ref doesn't use the AGit
+// magic namespace. Splitting the remainder into target vs. topic requires
+// knowing which branches actually exist - see resolveTargetAndTopic - since
+// a target branch name may itself contain `/`.
+func ParseAGitRef(ref string) (rest string, ok bool) {
+	rest = strings.TrimPrefix(ref, refPrefixAGit)
+	if rest == ref || rest == "" {
+		return "", false
+	}
+	return rest, true
+}
+
+// resolveTargetAndTopic splits rest (the part of an AGit ref after
+// `refs/for/`) into a target branch and an optional topic. Because target
+// branch names can themselves contain `/` (e.g. `release/1.0`), the split
+// can't just be "first segment is the target" - that would make such a
+// branch unreachable. Instead, following gitea/AGit semantics, we try the
+// longest possible prefix first and walk backwards until we find one that
+// names an existing branch; if none of the prefixes match an existing
+// branch, rest is treated as the target with no topic, same as a plain
+// `refs/for/<target>` push.
+func (c *Controller) resolveTargetAndTopic(ctx context.Context, repo *types.Repository, rest string) (
+	target string, topic string, err error,
+) {
+	segments := strings.Split(rest, "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		candidate := strings.Join(segments[:i], "/")
+		exists, err := c.git.BranchExists(ctx, repo.GitUID, candidate)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to check target branch existence: %w", err)
+		}
+		if exists {
+			return candidate, strings.Join(segments[i:], "/"), nil
+		}
+	}
+
+	return rest, "", nil
+}
+
+// AGitRefUpdate describes a single `refs/for/...` ref update as seen by the
+// pre-receive hook.
+type AGitRefUpdate struct {
+	Ref    string
+	OldSHA string
+	NewSHA string
+}
+
+// AGitResult is returned for every processed ref update so the hook can
+// render pktline status/info lines back to the pushing client.
+type AGitResult struct {
+	PullReq  *types.PullReq
+	Messages []string
+}
+
+// HandleAGitPush processes a single `refs/for/<target>[/<topic>]` ref update:
+// it creates a new PullReq the first time a (principal, target, topic) is
+// pushed, or fast-forwards/force-updates the SourceSHA of the existing one on
+// subsequent pushes. The pushed commit is only ever reachable through the
+// internal `refs/pull/<n>/head` ref; update.Ref itself is never created.
+func (c *Controller) HandleAGitPush(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	update AGitRefUpdate,
+	opts AGitPushOptions,
+) (*AGitResult, error) {
+	// Any principal who can read the target repo may open an AGit pull
+	// request against it - this is the same bar as pushing a branch and
+	// opening a PR through the API, just via a single `git push`.
+	session := &auth.Session{Principal: *principal}
+	if err := apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false); err != nil {
+		return nil, fmt.Errorf("failed to authorize agit push: %w", err)
+	}
+
+	rest, ok := ParseAGitRef(update.Ref)
+	if !ok {
+		return nil, fmt.Errorf("ref %q does not use the AGit magic namespace", update.Ref)
+	}
+
+	target, topicFromRef, err := c.resolveTargetAndTopic(ctx, repo, rest)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := opts.Topic
+	if topic == "" {
+		topic = topicFromRef
+	}
+	if topic == "" {
+		topic = defaultAGitTopic
+	}
+
+	targetBranchExists, err := c.git.BranchExists(ctx, repo.GitUID, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check target branch existence: %w", err)
+	}
+	if !targetBranchExists {
+		return nil, usererror.BadRequest(fmt.Sprintf("target branch %q does not exist", target))
+	}
+
+	sourceBranch := fmt.Sprintf(agitSourceBranchFormat, principal.ID, topic)
+
+	existing, err := c.pullreqStore.FindByBranches(ctx, repo.ID, sourceBranch, target)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to look up existing pull request: %w", err)
+	}
+
+	if existing == nil {
+		return c.createAGitPullReq(ctx, principal, repo, target, sourceBranch, update.NewSHA, opts)
+	}
+
+	return c.updateAGitPullReq(ctx, principal, repo, existing, update, opts)
+}
+
+func (c *Controller) createAGitPullReq(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	target, sourceBranch, newSHA string,
+	opts AGitPushOptions,
+) (*AGitResult, error) {
+	title := opts.Title
+	if title == "" {
+		title = fmt.Sprintf("AGit patch (%s)", sourceBranch)
+	}
+
+	pr := &types.PullReq{
+		CreatedBy:    principal.ID,
+		SourceRepoID: repo.ID,
+		TargetRepoID: repo.ID,
+		SourceBranch: sourceBranch,
+		TargetBranch: target,
+		SourceSHA:    newSHA,
+		Title:        title,
+		Description:  opts.Description,
+		State:        enum.PullReqStateOpen,
+	}
+
+	if err := c.pullreqStore.Create(ctx, pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request for AGit push: %w", err)
+	}
+
+	if err := c.git.UpdateRef(ctx, repo.GitUID, fmt.Sprintf(refFormatPullReqHead, pr.Number), newSHA); err != nil {
+		return nil, fmt.Errorf("failed to update internal pull request ref: %w", err)
+	}
+
+	if err := c.pullreqEvents.Created(ctx, principal.ID, pr, newSHA); err != nil {
+		return nil, fmt.Errorf("failed to emit pull request created event: %w", err)
+	}
+
+	return &AGitResult{
+		PullReq: pr,
+		Messages: []string{
+			fmt.Sprintf("Created pull request #%d.", pr.Number),
+			c.urlProvider.GenerateRepoPullReqURL(repo.Path, pr.Number),
+		},
+	}, nil
+}
+
+func (c *Controller) updateAGitPullReq(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	update AGitRefUpdate,
+	opts AGitPushOptions,
+) (*AGitResult, error) {
+	// Updates require the pushing principal to match the original author -
+	// renaming a user must not orphan their pull requests, which is why we
+	// compare PrincipalID and never username.
+	if pr.CreatedBy != principal.ID {
+		return nil, usererror.BadRequest(fmt.Sprintf("pull request #%d was opened by a different user", pr.Number))
+	}
+
+	oldSHA := pr.SourceSHA
+	fastForward, err := c.git.IsAncestor(ctx, repo.GitUID, oldSHA, update.NewSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check ref ancestry: %w", err)
+	}
+	forced := opts.ForcePush || !fastForward
+
+	pr.SourceSHA = update.NewSHA
+	if err := c.pullreqStore.Update(ctx, pr); err != nil {
+		return nil, fmt.Errorf("failed to update pull request source SHA: %w", err)
+	}
+
+	if err := c.git.UpdateRef(ctx, repo.GitUID, fmt.Sprintf(refFormatPullReqHead, pr.Number), update.NewSHA); err != nil {
+		return nil, fmt.Errorf("failed to update internal pull request ref: %w", err)
+	}
+
+	if err := c.pullreqEvents.BranchUpdated(ctx, principal.ID, pr, oldSHA, update.NewSHA, forced); err != nil {
+		return nil, fmt.Errorf("failed to emit pull request branch updated event: %w", err)
+	}
+
+	return &AGitResult{
+		PullReq: pr,
+		Messages: []string{
+			fmt.Sprintf("Updated pull request #%d.", pr.Number),
+			c.urlProvider.GenerateRepoPullReqURL(repo.Path, pr.Number),
+		},
+	}, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package githook implements the server-side handling of git's pre-receive
+// and post-receive hooks, including the AGit push-to-create-PR flow.
+package githook
+
+import (
+	"context"
+
+	"github.com/harness/gitness/internal/auth/authz"
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/types"
+)
+
+// gitInterface is the subset of the git backend the githook controller needs.
+// It is satisfied by the gitrpc client used throughout the api/controller
+// packages.
+type gitInterface interface {
+	BranchExists(ctx context.Context, gitUID, branch string) (bool, error)
+	UpdateRef(ctx context.Context, gitUID, ref, newSHA string) error
+
+	// IsAncestor reports whether ancestorSHA is reachable from descendantSHA,
+	// i.e. whether updating a ref from ancestorSHA to descendantSHA is a
+	// fast-forward.
+	IsAncestor(ctx context.Context, gitUID, ancestorSHA, descendantSHA string) (bool, error)
+}
+
+// pullReqEvents is the subset of the pullreq event reporter the githook
+// controller needs to keep AGit pushes indistinguishable, from a webhook
+// consumer's point of view, from pull requests created through the API.
+type pullReqEvents interface {
+	Created(ctx context.Context, principalID int64, pr *types.PullReq, sourceSHA string) error
+	BranchUpdated(ctx context.Context, principalID int64, pr *types.PullReq, oldSHA, newSHA string, forced bool) error
+}
+
+// urlProvider generates user-facing URLs for API responses and pktline
+// messages.
+type urlProvider interface {
+	GenerateRepoPullReqURL(repoPath string, pullReqNum int64) string
+}
+
+// Controller handles pre-receive/post-receive git hook callbacks.
+type Controller struct {
+	authorizer    authz.Authorizer
+	git           gitInterface
+	pullreqStore  store.PullReqStore
+	pullreqEvents pullReqEvents
+	urlProvider   urlProvider
+}
+
+// NewController creates a new githook Controller.
+func NewController(
+	authorizer authz.Authorizer,
+	git gitInterface,
+	pullreqStore store.PullReqStore,
+	pullreqEvents pullReqEvents,
+	urlProvider urlProvider,
+) *Controller {
+	return &Controller{
+		authorizer:    authorizer,
+		git:           git,
+		pullreqStore:  pullreqStore,
+		pullreqEvents: pullreqEvents,
+		urlProvider:   urlProvider,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+)
+
+// PreReceive is the entrypoint the git pre-receive hook calls (over the
+// gitrpc hook RPC) for every push. It recognizes refs pushed to the
+// `refs/for/` magic namespace and routes them through the AGit flow instead
+// of letting git create them as real refs; every other ref update passes
+// through unchanged so ordinary pushes are unaffected.
+func (c *Controller) PreReceive(ctx context.Context, in *PreReceiveInput) (*PreReceiveOutput, error) {
+	opts := ParseAGitPushOptions(in.PushOptions)
+
+	out := &PreReceiveOutput{}
+	for _, update := range in.RefUpdates {
+		if _, ok := ParseAGitRef(update.Ref); !ok {
+			// Not an AGit ref - let git write it as requested.
+			out.RefUpdates = append(out.RefUpdates, RefUpdateOutcome{Ref: update.Ref})
+			continue
+		}
+
+		result, err := c.HandleAGitPush(ctx, in.Principal, in.Repo, update, opts)
+		if err != nil {
+			return nil, fmt.Errorf("agit push to %q rejected: %w", update.Ref, err)
+		}
+
+		// The pushed commit is only ever reachable through the internal
+		// refs/pull/<n>/head ref created by HandleAGitPush - refs/for/...
+		// itself must never be written as a real ref.
+		out.RefUpdates = append(out.RefUpdates, RefUpdateOutcome{
+			Ref:      update.Ref,
+			Reject:   true,
+			Messages: result.Messages,
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import "github.com/harness/gitness/types"
+
+// PreReceiveInput is the payload git's pre-receive hook forwards to the API
+// for every push, one RefUpdate per ref the client is attempting to update.
+type PreReceiveInput struct {
+	Principal   *types.Principal
+	Repo        *types.Repository
+	RefUpdates  []AGitRefUpdate
+	PushOptions []string
+}
+
+// RefUpdateOutcome is the per-ref verdict returned from PreReceive: whether
+// git should proceed with writing the pushed Ref itself, plus any pktline
+// lines to relay back to the pushing client.
+type RefUpdateOutcome struct {
+	Ref      string
+	Reject   bool
+	Messages []string
+}
+
+// PreReceiveOutput collects one RefUpdateOutcome per ref update that was
+// part of the push.
+type PreReceiveOutput struct {
+	RefUpdates []RefUpdateOutcome
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pullreq exposes the pull request management APIs, including
+// merging - where branch protection rules like CODEOWNERS review
+// requirements are enforced.
+package pullreq
+
+import (
+	"context"
+
+	"github.com/harness/gitness/internal/auth/authz"
+	"github.com/harness/gitness/internal/services/codeowners"
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/types"
+)
+
+// gitInterface is the subset of the git backend the pullreq controller
+// needs to land a pull request.
+type gitInterface interface {
+	Merge(ctx context.Context, gitUID, targetBranch, sourceSHA, message string) (mergeSHA string, err error)
+}
+
+// reviewerStore is the subset of the reviewer store needed to evaluate
+// review-based branch protection rules at merge time.
+type reviewerStore interface {
+	ListForPullReq(ctx context.Context, pullReqID int64) ([]*types.PullReqReviewer, error)
+}
+
+// branchProtectionRules reports which branch protection rules are enabled
+// for a repo's target branch.
+type branchProtectionRules interface {
+	IsRuleEnabled(ctx context.Context, repoID int64, branch, ruleName string) (bool, error)
+}
+
+// Controller handles pull request management requests.
+type Controller struct {
+	authorizer      authz.Authorizer
+	git             gitInterface
+	repoStore       store.RepoStore
+	pullreqStore    store.PullReqStore
+	reviewerStore   reviewerStore
+	protectionRules branchProtectionRules
+	codeowners      *codeowners.Service
+}
+
+// NewController creates a new pullreq Controller.
+func NewController(
+	authorizer authz.Authorizer,
+	git gitInterface,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore reviewerStore,
+	protectionRules branchProtectionRules,
+	codeowners *codeowners.Service,
+) *Controller {
+	return &Controller{
+		authorizer:      authorizer,
+		git:             git,
+		repoStore:       repoStore,
+		pullreqStore:    pullreqStore,
+		reviewerStore:   reviewerStore,
+		protectionRules: protectionRules,
+		codeowners:      codeowners,
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/internal/api/auth"
+	"github.com/harness/gitness/internal/api/usererror"
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/internal/services/codeowners"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// gitReferenceNamePrefixBranch is the prefix of references of type branch.
+const gitReferenceNamePrefixBranch = "refs/heads/"
+
+// Merge merges a pull request's source branch into its target branch,
+// refusing the merge while a "require codeowner review" branch protection
+// rule is enabled on the target branch and any matched owner hasn't
+// approved yet.
+func (c *Controller) Merge(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullReqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullReqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if err := c.checkReviewGate(ctx, repo, pr); err != nil {
+		return nil, err
+	}
+
+	mergeSHA, err := c.git.Merge(ctx, repo.GitUID, pr.TargetBranch, pr.SourceSHA,
+		fmt.Sprintf("Merge pull request #%d", pr.Number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	pr.State = enum.PullReqStateMerged
+	pr.MergeSHA = mergeSHA
+	if err := c.pullreqStore.Update(ctx, pr); err != nil {
+		return nil, fmt.Errorf("failed to update merged pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// checkReviewGate enforces the codeowners.RequireReviewRuleName branch
+// protection rule, when it's enabled for pr's target branch: the merge is
+// rejected until every CODEOWNERS-matched owner has approved pr (an approval
+// counts whichever commit it was made on). It's a no-op (and the gate does nothing) when the rule isn't
+// enabled, which is why enabling it at all is what activates this check -
+// EvaluateReviewGate itself has no other caller.
+func (c *Controller) checkReviewGate(ctx context.Context, repo *types.Repository, pr *types.PullReq) error {
+	enabled, err := c.protectionRules.IsRuleEnabled(ctx, repo.ID, pr.TargetBranch, codeowners.RequireReviewRuleName)
+	if err != nil {
+		return fmt.Errorf("failed to check branch protection rules: %w", err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	reviews, err := c.reviewerStore.ListForPullReq(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	status, err := c.codeowners.EvaluateReviewGate(ctx, repo,
+		gitReferenceNamePrefixBranch+pr.TargetBranch, pr.MergeBaseSHA, pr.SourceSHA, reviews)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate codeowners review gate: %w", err)
+	}
+	if !status.Satisfied {
+		return usererror.BadRequest(fmt.Sprintf(
+			"pull request #%d requires approval from all CODEOWNERS owners before it can be merged", pr.Number))
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook exposes the webhook management APIs: CRUD on webhooks
+// themselves plus listing and redelivering their delivery executions.
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/api/usererror"
+	"github.com/harness/gitness/internal/auth/authz"
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/types"
+)
+
+// webhookExecutionStore is the subset of the store the controller needs to
+// list and redeliver webhook executions.
+type webhookExecutionStore interface {
+	Find(ctx context.Context, id int64) (*types.WebhookExecution, error)
+	CountForWebhook(ctx context.Context, webhookID int64) (int64, error)
+	ListForWebhook(ctx context.Context, webhookID int64, filter types.WebhookExecutionFilter) ([]*types.WebhookExecution, error)
+}
+
+// redeliverer re-sends the payload stored for a prior webhook execution.
+type redeliverer interface {
+	Redeliver(ctx context.Context, executionID int64) (*types.WebhookExecution, error)
+}
+
+// Controller handles webhook management and delivery inspection requests.
+type Controller struct {
+	authorizer            authz.Authorizer
+	repoStore             store.RepoStore
+	webhookStore          store.WebhookStore
+	webhookExecutionStore webhookExecutionStore
+	redeliverer           redeliverer
+}
+
+// NewController creates a new webhook Controller.
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	webhookStore store.WebhookStore,
+	webhookExecutionStore webhookExecutionStore,
+	redeliverer redeliverer,
+) *Controller {
+	return &Controller{
+		authorizer:            authorizer,
+		repoStore:             repoStore,
+		webhookStore:          webhookStore,
+		webhookExecutionStore: webhookExecutionStore,
+		redeliverer:           redeliverer,
+	}
+}
+
+// getWebhookVerifyOwnership loads a webhook and ensures it belongs to repoID,
+// so callers can't list or redeliver executions of a webhook on a repo they
+// don't otherwise have access to.
+func (c *Controller) getWebhookVerifyOwnership(ctx context.Context, repoID, webhookID int64) (*types.Webhook, error) {
+	webhook, err := c.webhookStore.Find(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	if webhook.RepoID != repoID {
+		return nil, usererror.NotFound("webhook not found")
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/internal/api/auth"
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListExecutions lists the most recent delivery attempts for a webhook,
+// newest first.
+func (c *Controller) ListExecutions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookID int64,
+	filter types.WebhookExecutionFilter,
+) ([]*types.WebhookExecution, int64, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, 0, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.webhookExecutionStore.CountForWebhook(ctx, webhook.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook executions: %w", err)
+	}
+
+	executions, err := c.webhookExecutionStore.ListForWebhook(ctx, webhook.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook executions: %w", err)
+	}
+
+	return executions, count, nil
+}
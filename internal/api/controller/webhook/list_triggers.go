@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListTriggers returns every trigger a webhook can be subscribed to. The
+// admin UI's webhook create/edit form calls this to populate its trigger
+// selection list, rather than hard-coding it client-side - so a trigger
+// added here (e.g. the pull request review ones) becomes selectable without
+// a separate frontend change.
+func (c *Controller) ListTriggers() []enum.WebhookTrigger {
+	return enum.WebhookTriggers
+}
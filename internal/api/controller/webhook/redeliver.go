@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/internal/api/auth"
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Redeliver re-sends the stored payload of a prior webhook execution,
+// recording the result as a new execution rather than mutating the original.
+func (c *Controller) Redeliver(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookID, executionID int64,
+) (*types.WebhookExecution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := c.webhookExecutionStore.Find(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook execution: %w", err)
+	}
+	if execution.WebhookID != webhook.ID {
+		return nil, fmt.Errorf("execution %d does not belong to webhook %d", executionID, webhookID)
+	}
+
+	return c.redeliverer.Redeliver(ctx, executionID)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package githook exposes the HTTP API the git pre-receive/post-receive
+// hook binary calls out to. The hook binary is responsible for turning the
+// JSON response into pktline status/info lines on the client's connection.
+package githook
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/internal/api/controller/githook"
+	"github.com/harness/gitness/internal/api/render"
+	"github.com/harness/gitness/internal/api/usererror"
+)
+
+// HandlePreReceive handles the pre-receive hook callback HTTP API.
+func HandlePreReceive(githookCtrl *githook.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(githook.PreReceiveInput)
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+			render.TranslatedUserError(w, usererror.BadRequest("failed to decode pre-receive payload"))
+			return
+		}
+
+		out, err := githookCtrl.PreReceive(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, out)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codeowners parses CODEOWNERS files and resolves the owners of a
+// set of changed paths, so callers can auto-request the right reviewers on
+// pull request create/update.
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// candidatePaths are tried, in order, to locate the CODEOWNERS file on a
+// branch - the first one found wins.
+var candidatePaths = []string{
+	".gitness/CODEOWNERS",
+	"docs/CODEOWNERS",
+	"CODEOWNERS",
+}
+
+// ruleKind controls how a Rule's compiled glob is applied to a path.
+// gobwas/glob, compiled with '/' as the separator, has no operator that
+// crosses a separator - not even "**", which is just two ordinary '*'
+// globs run together - so "match at any depth" can't be expressed inside
+// the pattern itself. Instead each depth-crossing pattern shape gets its
+// own matching strategy in Rule.matches.
+type ruleKind int
+
+const (
+	// ruleExact is an anchored or slash-containing pattern: matched as-is
+	// against the full path, with '*' bounded to a single segment.
+	ruleExact ruleKind = iota
+	// ruleBasename is a bare pattern (no '/' at all, e.g. `*.go`): matched
+	// against the final path segment, so it applies at every depth
+	// including the repo root.
+	ruleBasename
+	// ruleDirectory is a trailing-`/` pattern (e.g. `build/`): matched
+	// against every leading run of path segments, so it applies to files
+	// any number of levels below that directory.
+	ruleDirectory
+)
+
+// Rule is a single CODEOWNERS line: a gitignore-style path pattern and the
+// owner tokens (`@user`, `@team`, or an email address) assigned to it.
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	kind    ruleKind
+	matcher glob.Glob
+}
+
+// File is a parsed CODEOWNERS file. Rules are kept in file order; matching
+// uses last-match-wins semantics, same as git/GitHub's CODEOWNERS.
+type File struct {
+	Rules []Rule
+}
+
+// Parse reads a CODEOWNERS file, skipping blank lines and `#` comments.
+func Parse(content []byte) (*File, error) {
+	f := &File{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		pattern := fields[0]
+		kind, matchPattern := classifyPattern(pattern)
+		g, err := glob.Compile(matchPattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile CODEOWNERS pattern %q: %w", pattern, err)
+		}
+
+		f.Rules = append(f.Rules, Rule{
+			Pattern: pattern,
+			Owners:  fields[1:],
+			kind:    kind,
+			matcher: g,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan CODEOWNERS content: %w", err)
+	}
+
+	return f, nil
+}
+
+// classifyPattern strips the gitignore-style decorations off a CODEOWNERS
+// pattern (leading `/` anchor, trailing `/` directory marker) and reports
+// which ruleKind the remainder should be matched as.
+func classifyPattern(pattern string) (ruleKind, string) {
+	trimmed := strings.TrimPrefix(pattern, "/")
+	anchored := trimmed != pattern
+
+	if dir := strings.TrimSuffix(trimmed, "/"); dir != trimmed {
+		return ruleDirectory, dir
+	}
+	if !anchored && !strings.Contains(trimmed, "/") {
+		return ruleBasename, trimmed
+	}
+	return ruleExact, trimmed
+}
+
+// matches reports whether path is covered by this rule.
+func (r Rule) matches(path string) bool {
+	switch r.kind {
+	case ruleBasename:
+		return r.matcher.Match(basename(path))
+	case ruleDirectory:
+		segments := strings.Split(path, "/")
+		// i < len(segments) so the match is a directory *containing* path,
+		// never path itself.
+		for i := 1; i < len(segments); i++ {
+			if r.matcher.Match(strings.Join(segments[:i], "/")) {
+				return true
+			}
+		}
+		return false
+	default:
+		return r.matcher.Match(path)
+	}
+}
+
+// basename returns the final '/'-separated segment of path.
+func basename(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// OwnersFor returns the owner tokens of the last rule in the file that
+// matches path, or nil if no rule matches.
+func (f *File) OwnersFor(path string) []string {
+	var owners []string
+	for _, rule := range f.Rules {
+		if rule.matches(path) {
+			owners = rule.Owners
+		}
+	}
+	return owners
+}
+
+// OwnersForPaths returns the de-duplicated union of owner tokens across all
+// of paths, preserving first-seen order.
+func (f *File) OwnersForPaths(paths []string) []string {
+	seen := map[string]struct{}{}
+	var owners []string
+	for _, path := range paths {
+		for _, owner := range f.OwnersFor(path) {
+			if _, ok := seen[owner]; ok {
+				continue
+			}
+			seen[owner] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
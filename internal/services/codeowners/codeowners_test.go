@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import "testing"
+
+func TestFileOwnersFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		want    string
+	}{
+		{
+			name:    "bare pattern matches root",
+			content: "*.go @root-owner\n",
+			path:    "main.go",
+			want:    "@root-owner",
+		},
+		{
+			name:    "bare pattern matches nested path",
+			content: "*.go @root-owner\n",
+			path:    "a/b/c.go",
+			want:    "@root-owner",
+		},
+		{
+			name:    "directory pattern matches direct child",
+			content: "dir/ @dir-owner\n",
+			path:    "dir/a.go",
+			want:    "@dir-owner",
+		},
+		{
+			name:    "directory pattern matches deeply nested child",
+			content: "dir/ @dir-owner\n",
+			path:    "dir/a/b.go",
+			want:    "@dir-owner",
+		},
+		{
+			name:    "directory pattern does not match sibling",
+			content: "dir/ @dir-owner\n",
+			path:    "other/a.go",
+			want:    "",
+		},
+		{
+			name:    "anchored pattern matches only at root",
+			content: "/main.go @root-owner\n",
+			path:    "a/main.go",
+			want:    "",
+		},
+		{
+			name:    "last match wins",
+			content: "*.go @go-owner\ndir/*.go @dir-go-owner\n",
+			path:    "dir/a.go",
+			want:    "@dir-go-owner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			owners := file.OwnersFor(tt.path)
+
+			var got string
+			if len(owners) > 0 {
+				got = owners[len(owners)-1]
+			}
+			if got != tt.want {
+				t.Errorf("OwnersFor(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReviewGateStatus is the result of evaluating the RequireReviewRuleName
+// branch protection rule for a pull request.
+type ReviewGateStatus struct {
+	// Satisfied is true once every CODEOWNERS-matched owner has an approving
+	// review. The commit an approval was made on is not checked.
+	Satisfied bool
+	// Pending lists the matched owners who have not yet approved. Empty when
+	// Satisfied is true, or when the repo has no CODEOWNERS file (the rule
+	// has nothing to enforce).
+	Pending []*types.Principal
+}
+
+// EvaluateReviewGate implements the RequireReviewRuleName branch protection
+// rule: it resolves the CODEOWNERS owners of the files changed between
+// baseSHA and headSHA, then reports which of them have not yet approved.
+// The merge endpoint is expected to call this - when a branch protection
+// rule on the target branch has RequireReviewRuleName enabled - and reject
+// the merge while Satisfied is false.
+func (s *Service) EvaluateReviewGate(
+	ctx context.Context,
+	repo *types.Repository,
+	targetRef, baseSHA, headSHA string,
+	reviews []*types.PullReqReviewer,
+) (*ReviewGateStatus, error) {
+	owners, err := s.ReviewersForChange(ctx, repo, targetRef, baseSHA, headSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve codeowners for review gate: %w", err)
+	}
+	if len(owners) == 0 {
+		return &ReviewGateStatus{Satisfied: true}, nil
+	}
+
+	approved := map[int64]bool{}
+	for _, review := range reviews {
+		if review.ReviewDecision == enum.PullReqReviewDecisionApproved {
+			approved[review.ReviewerID] = true
+		}
+	}
+
+	var pending []*types.Principal
+	for _, owner := range owners {
+		if !approved[owner.ID] {
+			pending = append(pending, owner)
+		}
+	}
+
+	return &ReviewGateStatus{
+		Satisfied: len(pending) == 0,
+		Pending:   pending,
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeowners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/types"
+)
+
+// RequireReviewRuleName is the branch protection rule key gating merges on
+// every CODEOWNERS-matched owner having approved. It's evaluated the same
+// way as the built-in required-reviewers rule, just sourced from
+// ReviewersForChange instead of a static reviewer list.
+const RequireReviewRuleName = "require_codeowner_review"
+
+// gitInterface is the subset of the git backend needed to read the
+// CODEOWNERS file and diff changed paths between two commits.
+type gitInterface interface {
+	ReadFile(ctx context.Context, gitUID, ref, path string) ([]byte, error)
+	DiffPaths(ctx context.Context, gitUID, baseSHA, headSHA string) ([]string, error)
+}
+
+// teamStore is the subset of the team store needed to expand a CODEOWNERS
+// `@team` token into its member principals.
+type teamStore interface {
+	FindByUID(ctx context.Context, uid string) (*types.Team, error)
+}
+
+// Service loads and evaluates CODEOWNERS rules for a repository.
+type Service struct {
+	git            gitInterface
+	principalStore store.PrincipalStore
+	teamStore      teamStore
+}
+
+// NewService creates a new codeowners Service.
+func NewService(git gitInterface, principalStore store.PrincipalStore, teamStore teamStore) *Service {
+	return &Service{git: git, principalStore: principalStore, teamStore: teamStore}
+}
+
+// Load reads the CODEOWNERS file from the first of candidatePaths found on
+// ref, returning (nil, nil) if none of the repo's target locations has one.
+func (s *Service) Load(ctx context.Context, gitUID, ref string) (*File, error) {
+	for _, path := range candidatePaths {
+		content, err := s.git.ReadFile(ctx, gitUID, ref, path)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		return Parse(content)
+	}
+
+	return nil, nil
+}
+
+// ReviewersForChange resolves the CODEOWNERS-assigned reviewers for the
+// files changed between baseSHA and headSHA on targetRef. It returns an
+// empty slice (not an error) when the repo has no CODEOWNERS file.
+func (s *Service) ReviewersForChange(
+	ctx context.Context,
+	repo *types.Repository,
+	targetRef, baseSHA, headSHA string,
+) ([]*types.Principal, error) {
+	file, err := s.Load(ctx, repo.GitUID, targetRef)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, nil
+	}
+
+	paths, err := s.git.DiffPaths(ctx, repo.GitUID, baseSHA, headSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff changed paths: %w", err)
+	}
+
+	return s.resolveOwners(ctx, file.OwnersForPaths(paths))
+}
+
+// resolveOwners turns CODEOWNERS owner tokens (`@user`, `@team`,
+// `user@example.com`) into Principals, silently dropping tokens that don't
+// resolve to an existing principal or team rather than failing the whole
+// lookup - a typo in CODEOWNERS shouldn't block every PR from getting
+// reviewers.
+func (s *Service) resolveOwners(ctx context.Context, owners []string) ([]*types.Principal, error) {
+	var principals []*types.Principal
+	for _, owner := range owners {
+		switch {
+		case strings.HasPrefix(owner, "@"):
+			members, err := s.resolveAtOwner(ctx, strings.TrimPrefix(owner, "@"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve codeowners entry %q: %w", owner, err)
+			}
+			principals = append(principals, members...)
+		case strings.Contains(owner, "@"):
+			p, err := s.principalStore.FindByEmail(ctx, owner)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve codeowners entry %q: %w", owner, err)
+			}
+			principals = append(principals, p)
+		}
+	}
+
+	return principals, nil
+}
+
+// resolveAtOwner resolves a `@handle` CODEOWNERS token, which may name
+// either a user or a team. A user UID is tried first since it's the common
+// case; if none matches, the handle is looked up as a team and expanded to
+// its member principals, so a team entry assigns review to everyone on it
+// rather than being silently dropped.
+func (s *Service) resolveAtOwner(ctx context.Context, uid string) ([]*types.Principal, error) {
+	p, err := s.principalStore.FindByUID(ctx, uid)
+	if err == nil {
+		return []*types.Principal{p}, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	team, err := s.teamStore.FindByUID(ctx, uid)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*types.Principal, 0, len(team.MemberPrincipalIDs))
+	for _, id := range team.MemberPrincipalIDs {
+		member, err := s.principalStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve member of team %q: %w", uid, err)
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
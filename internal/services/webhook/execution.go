@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// retryBackoff is the fixed delay schedule applied to failed deliveries
+// (5xx responses or timeouts). Index i is the delay before attempt i+2.
+// After len(retryBackoff)+1 total attempts the delivery is left failed.
+var retryBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+}
+
+const (
+	maxDeliveryAttempts = len(retryBackoff) + 1
+
+	// maxWebhookResponseBodyBytes caps how much of a receiver's response body
+	// we persist per delivery attempt, to keep runaway responses from
+	// bloating the webhook_executions table.
+	maxWebhookResponseBodyBytes = 16 * 1024
+)
+
+// dispatch translates payload into webhook.PayloadFormat and sends it,
+// for the first attempt at a brand-new delivery. Redelivering an existing
+// execution must not go through here - the stored RequestBody is already
+// translated, and translating it a second time either fails (formats like
+// github expect the native struct, not a generic map) or double-wraps it
+// (cloudevents); see send.
+func (s *Service) dispatch(
+	ctx context.Context,
+	webhook *types.Webhook,
+	trigger enum.WebhookTrigger,
+	payload any,
+	attempt int,
+) (*types.WebhookExecution, error) {
+	translated, err := translatePayload(trigger, PayloadFormat(webhook.PayloadFormat), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate webhook payload to %q format: %w", webhook.PayloadFormat, err)
+	}
+
+	body, err := json.Marshal(translated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return s.send(ctx, webhook, trigger, body, attempt)
+}
+
+// send delivers the already-translated, already-marshaled body to
+// webhook.URL, persisting the attempt as a types.WebhookExecution and
+// signing the body when webhook.Secret is set. On a retryable failure (5xx
+// or timeout) it schedules a retry following retryBackoff, up to
+// maxDeliveryAttempts attempts in total. It returns the execution record
+// created for this attempt.
+func (s *Service) send(
+	ctx context.Context,
+	webhook *types.Webhook,
+	trigger enum.WebhookTrigger,
+	body []byte,
+	attempt int,
+) (*types.WebhookExecution, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range deliveryHeaders(trigger) {
+		req.Header.Set(k, v)
+	}
+	if webhook.Secret != "" {
+		req.Header.Set(headerSignature, signPayload(webhook.Secret, body))
+	}
+
+	execution := &types.WebhookExecution{
+		WebhookID:      webhook.ID,
+		Trigger:        trigger,
+		RequestHeaders: req.Header,
+		RequestBody:    string(body),
+		Attempt:        attempt,
+		Created:        time.Now().UnixMilli(),
+	}
+
+	start := time.Now()
+	resp, sendErr := s.httpClient.Do(req)
+	execution.Duration = time.Since(start)
+
+	// retryable reflects whether *this kind* of failure is ever worth
+	// retrying (5xx and send errors/timeouts are; 4xx is not), independent
+	// of whether we've exhausted attempts.
+	retryable := false
+	if sendErr != nil {
+		execution.ResponseBody = sendErr.Error()
+		retryable = true
+	} else {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBodyBytes))
+		execution.ResponseStatusCode = resp.StatusCode
+		execution.ResponseBody = string(respBody)
+		retryable = resp.StatusCode >= http.StatusInternalServerError
+	}
+
+	willRetry := retryable && attempt < maxDeliveryAttempts
+	execution.Result = resultFor(sendErr == nil && resp != nil && resp.StatusCode < http.StatusMultipleChoices, willRetry)
+
+	if err := s.webhookExecutionStore.Create(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to persist webhook execution: %w", err)
+	}
+
+	if willRetry {
+		delay := retryBackoff[attempt-1]
+		if err := s.scheduler.ScheduleAfter(delay, func(ctx context.Context) error {
+			_, err := s.send(ctx, webhook, trigger, body, attempt+1)
+			return err
+		}); err != nil {
+			return execution, err
+		}
+		return execution, nil
+	}
+
+	if sendErr != nil {
+		return execution, fmt.Errorf("failed to send webhook after %d attempt(s): %w", attempt, sendErr)
+	}
+
+	return execution, nil
+}
+
+// resultFor maps the outcome of one delivery attempt to the stored result
+// enum: a successful (2xx) response is always Success; anything else is
+// Retriable while a retry is actually scheduled, and only becomes Fatal once
+// no further attempt will be made (a non-retryable status like 4xx, or a
+// retryable failure that has exhausted maxDeliveryAttempts).
+func resultFor(success, willRetry bool) enum.WebhookExecutionResult {
+	switch {
+	case success:
+		return enum.WebhookExecutionResultSuccess
+	case willRetry:
+		return enum.WebhookExecutionResultRetriableError
+	default:
+		return enum.WebhookExecutionResultFatalError
+	}
+}
+
+// Redeliver re-sends the exact body stored for a prior execution, recording
+// it as a brand-new attempt rather than mutating the original. It resends
+// original.RequestBody verbatim through send rather than dispatch: that body
+// is already translated to the webhook's PayloadFormat, and running it back
+// through translatePayload a second time either fails (translators for
+// formats like github only accept the native payload struct, not the
+// generic map a re-unmarshal produces) or corrupts the body (cloudevents
+// would get wrapped in a second envelope).
+func (s *Service) Redeliver(ctx context.Context, executionID int64) (*types.WebhookExecution, error) {
+	original, err := s.webhookExecutionStore.Find(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook execution: %w", err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, original.WebhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	redelivered, err := s.send(ctx, webhook, original.Trigger, []byte(original.RequestBody), 1)
+	if err != nil {
+		return redelivered, err
+	}
+
+	return redelivered, nil
+}
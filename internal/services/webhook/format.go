@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// PayloadFormat controls the wire shape a webhook's outgoing payload is
+// translated to before dispatch. It's a per-webhook setting so a single
+// gitness instance can feed both gitness-native consumers and tools that
+// only understand another forge's webhook shape.
+type PayloadFormat string
+
+const (
+	// PayloadFormatNative is the gitness-native payload shape (the Go
+	// structs defined next to each handleEvent* function), unmodified.
+	PayloadFormatNative PayloadFormat = "native"
+
+	// PayloadFormatGithub translates the payload into the equivalent
+	// GitHub webhook event shape, for tools that only speak GitHub.
+	PayloadFormatGithub PayloadFormat = "github"
+
+	// PayloadFormatCloudEvents wraps the native payload in a CloudEvents 1.0
+	// envelope.
+	PayloadFormatCloudEvents PayloadFormat = "cloudevents"
+)
+
+// translatorKey identifies one (trigger, format) translator in the registry.
+type translatorKey struct {
+	trigger enum.WebhookTrigger
+	format  PayloadFormat
+}
+
+// translateFunc converts a native payload (as produced by a handleEvent*
+// closure) into the wire shape for format.
+type translateFunc func(trigger enum.WebhookTrigger, native any) (any, error)
+
+// translators holds every registered (trigger, format) -> translateFunc
+// mapping. Entries are added via registerTranslator from each payload's
+// file, at package init time.
+var translators = map[translatorKey]translateFunc{}
+
+// registerTranslator adds fn as the translator for (trigger, format). It
+// panics on a duplicate registration, since that can only be a programming
+// error (two translators registered for the same pair).
+func registerTranslator(trigger enum.WebhookTrigger, format PayloadFormat, fn translateFunc) {
+	key := translatorKey{trigger: trigger, format: format}
+	if _, ok := translators[key]; ok {
+		panic(fmt.Sprintf("webhook: duplicate translator registered for trigger=%s format=%s", trigger, format))
+	}
+	translators[key] = fn
+}
+
+// translatePayload converts native into the wire shape for format. Native
+// format and triggers without a registered translator for format both pass
+// native through unchanged, so adding a new trigger doesn't require adding
+// every translator before it can be dispatched.
+func translatePayload(trigger enum.WebhookTrigger, format PayloadFormat, native any) (any, error) {
+	if format == "" || format == PayloadFormatNative {
+		return native, nil
+	}
+
+	fn, ok := translators[translatorKey{trigger: trigger, format: format}]
+	if !ok {
+		return native, nil
+	}
+
+	return fn(trigger, native)
+}
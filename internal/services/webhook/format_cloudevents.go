@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/harness/gitness/types/enum"
+)
+
+// cloudEventsSpecVersion is the CloudEvents spec version gitness emits.
+const cloudEventsSpecVersion = "1.0"
+
+// cloudEventsSource identifies gitness as the CloudEvents source; the repo
+// in the BaseSegment of the wrapped payload disambiguates further.
+const cloudEventsSource = "gitness"
+
+// cloudEvent is a CloudEvents 1.0 envelope around a native gitness payload.
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	Type            string `json:"type"`
+	Source          string `json:"source"`
+	ID              string `json:"id"`
+	Subject         string `json:"subject,omitempty"`
+	DataContentType string `json:"datacontenttype"`
+	Data            any    `json:"data"`
+}
+
+// cloudEventType turns a WebhookTrigger like "pullreq_created" into the
+// CloudEvents reverse-DNS type `dev.gitness.pullreq.created.v1`.
+func cloudEventType(trigger enum.WebhookTrigger) string {
+	parts := strings.SplitN(strings.TrimPrefix(string(trigger), "pullreq_"), "_", 2)
+	name := strings.Join(parts, ".")
+	return fmt.Sprintf("dev.gitness.pullreq.%s.v1", name)
+}
+
+// translateToCloudEvents wraps any native payload in a CloudEvents envelope.
+// It's registered once per trigger below rather than once globally, since
+// the registry is keyed by (trigger, format) - triggers added after this
+// file without an explicit registration fall back to the native payload.
+func translateToCloudEvents(trigger enum.WebhookTrigger, native any) (any, error) {
+	return &cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		Type:            cloudEventType(trigger),
+		Source:          cloudEventsSource,
+		ID:              uuid.NewString(),
+		DataContentType: "application/json",
+		Data:            native,
+	}, nil
+}
+
+func init() {
+	for _, trigger := range []enum.WebhookTrigger{
+		enum.WebhookTriggerPullReqCreated,
+		enum.WebhookTriggerPullReqReopened,
+		enum.WebhookTriggerPullReqBranchUpdated,
+		enum.WebhookTriggerPullReqReviewSubmitted,
+		enum.WebhookTriggerPullReqReviewerAdded,
+		enum.WebhookTriggerPullReqReviewerRemoved,
+	} {
+		registerTranslator(trigger, PayloadFormatCloudEvents, translateToCloudEvents)
+	}
+}
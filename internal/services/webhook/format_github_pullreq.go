@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// githubPullRequestEvent mirrors the subset of GitHub's `pull_request`
+// webhook event shape that CI bots and chat integrations key off of.
+type githubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	Number      int64             `json:"number"`
+	PullRequest githubPullRequest `json:"pull_request"`
+	Repository  githubRepository  `json:"repository"`
+	Sender      githubUser        `json:"sender"`
+}
+
+type githubPullRequest struct {
+	Number  int64      `json:"number"`
+	Title   string     `json:"title"`
+	State   string     `json:"state"`
+	HTMLURL string     `json:"html_url"`
+	Head    githubRef  `json:"head"`
+	Base    githubRef  `json:"base"`
+	User    githubUser `json:"user"`
+}
+
+type githubRef struct {
+	Ref  string           `json:"ref"`
+	SHA  string           `json:"sha"`
+	Repo githubRepository `json:"repo"`
+}
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubActionFor maps a gitness pull request trigger to the GitHub
+// `action` field consumers branch on.
+func githubActionFor(trigger enum.WebhookTrigger) (string, error) {
+	switch trigger {
+	case enum.WebhookTriggerPullReqCreated:
+		return "opened", nil
+	case enum.WebhookTriggerPullReqReopened:
+		return "reopened", nil
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return "synchronize", nil
+	default:
+		return "", fmt.Errorf("no github action mapping for trigger %q", trigger)
+	}
+}
+
+// translateToGithubPullRequest converts any of the native pull request
+// payloads (Created/Reopened/BranchUpdated all share the same shape up to
+// embedding) into a GitHub-compatible `pull_request` event.
+func translateToGithubPullRequest(trigger enum.WebhookTrigger, native any) (any, error) {
+	action, err := githubActionFor(trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		base      BaseSegment
+		pr        PullReqSegment
+		targetRef PullReqTargetReferenceSegment
+		ref       ReferenceSegment
+	)
+
+	switch p := native.(type) {
+	case *PullReqCreatedPayload:
+		base, pr, targetRef, ref = p.BaseSegment, p.PullReqSegment, p.PullReqTargetReferenceSegment, p.ReferenceSegment
+	case *PullReqReopenedPayload:
+		base, pr, targetRef, ref = p.BaseSegment, p.PullReqSegment, p.PullReqTargetReferenceSegment, p.ReferenceSegment
+	case *PullReqBranchUpdatedPayload:
+		base, pr, targetRef, ref = p.BaseSegment, p.PullReqSegment, p.PullReqTargetReferenceSegment, p.ReferenceSegment
+	default:
+		return nil, fmt.Errorf("translateToGithubPullRequest: unsupported payload type %T", native)
+	}
+
+	repo := githubRepository{FullName: base.Repo.Path, HTMLURL: base.Repo.URL}
+
+	return &githubPullRequestEvent{
+		Action: action,
+		Number: pr.PullReq.Number,
+		PullRequest: githubPullRequest{
+			Number:  pr.PullReq.Number,
+			Title:   pr.PullReq.Title,
+			State:   "open",
+			HTMLURL: fmt.Sprintf("%s/pulls/%d", base.Repo.URL, pr.PullReq.Number),
+			Head: githubRef{
+				Ref:  ref.Ref.Name,
+				Repo: githubRepository{FullName: ref.Ref.Repo.Path, HTMLURL: ref.Ref.Repo.URL},
+			},
+			Base: githubRef{
+				Ref:  targetRef.TargetRef.Name,
+				Repo: githubRepository{FullName: targetRef.TargetRef.Repo.Path, HTMLURL: targetRef.TargetRef.Repo.URL},
+			},
+			User: githubUser{Login: base.Principal.UID},
+		},
+		Repository: repo,
+		Sender:     githubUser{Login: base.Principal.UID},
+	}, nil
+}
+
+func init() {
+	registerTranslator(enum.WebhookTriggerPullReqCreated, PayloadFormatGithub, translateToGithubPullRequest)
+	registerTranslator(enum.WebhookTriggerPullReqReopened, PayloadFormatGithub, translateToGithubPullRequest)
+	registerTranslator(enum.WebhookTriggerPullReqBranchUpdated, PayloadFormatGithub, translateToGithubPullRequest)
+}
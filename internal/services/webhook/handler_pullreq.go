@@ -36,12 +36,18 @@ type PullReqCreatedPayload struct {
 	PullReqTargetReferenceSegment
 	ReferenceSegment
 	ReferenceDetailsSegment
+	CodeownersSegment
 }
 
 // handleEventPullReqCreated handles created events for pull requests
 // and triggers pullreq created webhooks for the source repo.
 func (s *Service) handleEventPullReqCreated(ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	// Shared across every matching webhook's payload-building call below, so
+	// CODEOWNERS reviewers are requested once for this event, not once per
+	// webhook - see codeownersAssignment.
+	codeowners := &codeownersAssignment{}
+
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCreated,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
@@ -52,6 +58,14 @@ func (s *Service) handleEventPullReqCreated(ctx context.Context,
 			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
 			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
 
+			// Auto-request CODEOWNERS reviewers before the webhook fires, so
+			// consumers see the resolved owner set on the very first event.
+			// This only runs when a webhook subscribes to this trigger.
+			codeownersSegment, err := s.codeownersSegmentFor(ctx, codeowners, pr, targetRepo, pr.MergeBaseSHA, event.Payload.SourceSHA)
+			if err != nil {
+				return nil, err
+			}
+
 			return &PullReqCreatedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqCreated,
@@ -77,18 +91,29 @@ func (s *Service) handleEventPullReqCreated(ctx context.Context,
 					SHA:    event.Payload.SourceSHA,
 					Commit: &commitInfo,
 				},
+				CodeownersSegment: codeownersSegment,
 			}, nil
 		})
 }
 
 // PullReqReopenedPayload describes the body of the pullreq reopened trigger.
-// Note: same as payload for created.
-type PullReqReopenedPayload PullReqCreatedPayload
+// Note: same as payload for created, including the codeowners reviewers,
+// which are re-requested on reopen.
+type PullReqReopenedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	ReferenceDetailsSegment
+	CodeownersSegment
+}
 
 // handleEventPullReqReopened handles reopened events for pull requests
 // and triggers pullreq reopened webhooks for the source repo.
 func (s *Service) handleEventPullReqReopened(ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	codeowners := &codeownersAssignment{}
+
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReopened,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
@@ -99,6 +124,11 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
 			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
 
+			codeownersSegment, err := s.codeownersSegmentFor(ctx, codeowners, pr, targetRepo, pr.MergeBaseSHA, event.Payload.SourceSHA)
+			if err != nil {
+				return nil, err
+			}
+
 			return &PullReqReopenedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqReopened,
@@ -124,6 +154,7 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 					SHA:    event.Payload.SourceSHA,
 					Commit: &commitInfo,
 				},
+				CodeownersSegment: codeownersSegment,
 			}, nil
 		})
 }
@@ -137,12 +168,15 @@ type PullReqBranchUpdatedPayload struct {
 	ReferenceSegment
 	ReferenceDetailsSegment
 	ReferenceUpdateSegment
+	CodeownersSegment
 }
 
 // handleEventPullReqBranchUpdated handles branch updated events for pull requests
 // and triggers pullreq branch updated webhooks for the source repo.
 func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	codeowners := &codeownersAssignment{}
+
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqBranchUpdated,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
@@ -153,6 +187,15 @@ func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
 			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
 
+			// Re-evaluate CODEOWNERS against exactly what changed in this
+			// update, not the whole PR diff, so a later push that touches a
+			// newly-owned path still picks up its reviewer.
+			codeownersSegment, err := s.codeownersSegmentFor(ctx, codeowners, pr, targetRepo,
+				event.Payload.OldSHA, event.Payload.NewSHA)
+			if err != nil {
+				return nil, err
+			}
+
 			return &PullReqBranchUpdatedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqBranchUpdated,
@@ -182,6 +225,7 @@ func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 					OldSHA: event.Payload.OldSHA,
 					Forced: event.Payload.Forced,
 				},
+				CodeownersSegment: codeownersSegment,
 			}, nil
 		})
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	pullreqevents "github.com/harness/gitness/internal/events/pullreq"
+)
+
+// Register subscribes every pull request event this service knows how to
+// turn into a webhook delivery to reader. Without this, handleEventPullReq*
+// are never invoked - the event reader has no other way to learn about them.
+func (s *Service) Register(reader *pullreqevents.Reader) error {
+	if err := reader.RegisterCreated(s.handleEventPullReqCreated); err != nil {
+		return err
+	}
+	if err := reader.RegisterReopened(s.handleEventPullReqReopened); err != nil {
+		return err
+	}
+	if err := reader.RegisterBranchUpdated(s.handleEventPullReqBranchUpdated); err != nil {
+		return err
+	}
+	if err := reader.RegisterReviewSubmitted(s.handleEventPullReqReviewSubmitted); err != nil {
+		return err
+	}
+	if err := reader.RegisterReviewerAdded(s.handleEventPullReqReviewerAdded); err != nil {
+		return err
+	}
+	if err := reader.RegisterReviewerRemoved(s.handleEventPullReqReviewerRemoved); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harness/gitness/types"
+)
+
+// CodeownersSegment carries the reviewers that were auto-requested from
+// CODEOWNERS when the event fired, so webhook consumers don't have to fetch
+// the pull request back to see who was assigned.
+type CodeownersSegment struct {
+	CodeownersReviewers []PrincipalInfo `json:"codeowners_reviewers,omitempty"`
+}
+
+// codeownersAssignment memoizes the outcome of resolving and requesting
+// CODEOWNERS reviewers for a single event. A repo can have many webhooks
+// subscribed to the same trigger, and the payload for each is built by a
+// separate call into codeownersSegmentFor; without memoizing here, each of
+// those calls would re-run RequestReviewers. One codeownersAssignment is
+// created per handleEventPullReq* invocation and shared across every
+// webhook's payload-building call for that one event, so the reviewers are
+// only ever requested once per event.
+type codeownersAssignment struct {
+	once    sync.Once
+	segment CodeownersSegment
+	err     error
+}
+
+// codeownersSegmentFor resolves the CODEOWNERS-assigned reviewers for pr and
+// requests them at most once per assignment, regardless of how many times
+// it's called for that same assignment (i.e. once per matching webhook).
+// It is only called from the payload-building func, so when no enabled
+// webhook on the target repo subscribes to the trigger, no CODEOWNERS
+// reviewers are requested for that event. It returns an empty segment when the service has no codeowners evaluator
+// configured or the repo has no CODEOWNERS file.
+func (s *Service) codeownersSegmentFor(
+	ctx context.Context,
+	assignment *codeownersAssignment,
+	pr *types.PullReq,
+	targetRepo *types.Repository,
+	baseSHA, headSHA string,
+) (CodeownersSegment, error) {
+	assignment.once.Do(func() {
+		assignment.segment, assignment.err = s.resolveAndRequestCodeownersReviewers(ctx, pr, targetRepo, baseSHA, headSHA)
+	})
+	return assignment.segment, assignment.err
+}
+
+// resolveAndRequestCodeownersReviewers does the actual CODEOWNERS lookup and
+// the single, idempotent reviewer-request call. It must only be invoked once
+// per event - see codeownersAssignment.
+func (s *Service) resolveAndRequestCodeownersReviewers(
+	ctx context.Context,
+	pr *types.PullReq,
+	targetRepo *types.Repository,
+	baseSHA, headSHA string,
+) (CodeownersSegment, error) {
+	if s.codeowners == nil {
+		return CodeownersSegment{}, nil
+	}
+
+	reviewers, err := s.codeowners.ReviewersForChange(ctx, targetRepo,
+		gitReferenceNamePrefixBranch+pr.TargetBranch, baseSHA, headSHA)
+	if err != nil {
+		return CodeownersSegment{}, err
+	}
+	if len(reviewers) == 0 {
+		return CodeownersSegment{}, nil
+	}
+
+	// RequestReviewers is expected to be a no-op for reviewers who were
+	// already requested on this pull request, making this call safe even if
+	// the same CODEOWNERS owner set is resolved again on a later event.
+	if err := s.reviewerStore.RequestReviewers(ctx, pr.ID, reviewers); err != nil {
+		return CodeownersSegment{}, err
+	}
+
+	segment := CodeownersSegment{}
+	for _, reviewer := range reviewers {
+		segment.CodeownersReviewers = append(segment.CodeownersReviewers, principalInfoFrom(reviewer))
+	}
+
+	return segment, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	headerEventID    = "X-Gitness-Delivery"
+	headerEventType  = "X-Gitness-Event"
+	headerTrigger    = "X-Gitness-Trigger"
+	headerSignature  = "X-Gitness-Signature-256"
+	signaturePrefix  = "sha256="
+	eventTypeDefault = "pullreq"
+)
+
+// signPayload computes the hex-encoded HMAC-SHA256 signature of body using
+// secret, in the `sha256=<hex>` form clients are expected to verify against
+// the X-Gitness-Signature-256 header.
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliveryHeaders returns the standard headers attached to every outgoing
+// webhook request, in addition to the signature header added separately
+// when the webhook has a Secret configured.
+func deliveryHeaders(trigger enum.WebhookTrigger) map[string]string {
+	return map[string]string{
+		headerEventID:   uuid.NewString(),
+		headerEventType: eventTypeDefault,
+		headerTrigger:   string(trigger),
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// pullReqEventPayloadFunc builds the native payload for one pull request
+// event, once the principal, pull request, and its target/source repos
+// have been loaded. It may be called once per webhook subscribed to
+// trigger - handlers that do a side effect from it (e.g. auto-requesting
+// CODEOWNERS reviewers) must guard themselves against running more than
+// once, see codeownersAssignment.
+type pullReqEventPayloadFunc func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error)
+
+// triggerForEventWithPullReq is the shared entrypoint every
+// handleEventPullReq* handler uses: it loads the principal, pull request,
+// and target/source repos an event refers to, then, for every webhook on
+// the target repo subscribed to trigger, builds that webhook's payload via
+// fn and sends it through dispatch - the path that signs, persists, and
+// retries the delivery.
+func (s *Service) triggerForEventWithPullReq(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	principalID int64,
+	pullReqID int64,
+	fn pullReqEventPayloadFunc,
+) error {
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal for event %s: %w", eventID, err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request for event %s: %w", eventID, err)
+	}
+
+	targetRepo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repo for event %s: %w", eventID, err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find source repo for event %s: %w", eventID, err)
+		}
+	}
+
+	webhooks, err := s.webhookStore.ListForRepo(ctx, targetRepo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks for event %s: %w", eventID, err)
+	}
+
+	for _, wh := range webhooks {
+		if !wh.Enabled || !subscribesToTrigger(wh, trigger) {
+			continue
+		}
+
+		payload, err := fn(principal, pr, targetRepo, sourceRepo)
+		if err != nil {
+			return fmt.Errorf("failed to build payload for event %s: %w", eventID, err)
+		}
+
+		if _, err := s.dispatch(ctx, wh, trigger, payload, 1); err != nil {
+			return fmt.Errorf("failed to dispatch webhook %d for event %s: %w", wh.ID, eventID, err)
+		}
+	}
+
+	return nil
+}
+
+// subscribesToTrigger reports whether wh is configured to fire on trigger.
+func subscribesToTrigger(wh *types.Webhook, trigger enum.WebhookTrigger) bool {
+	for _, t := range wh.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// WebhookTrigger is an event that triggers a webhook.
+type WebhookTrigger string
+
+const (
+	WebhookTriggerPullReqCreated       WebhookTrigger = "pullreq_created"
+	WebhookTriggerPullReqReopened      WebhookTrigger = "pullreq_reopened"
+	WebhookTriggerPullReqBranchUpdated WebhookTrigger = "pullreq_branch_updated"
+
+	// WebhookTriggerPullReqReviewSubmitted fires whenever a reviewer submits
+	// a review (approve, request changes, or comment) on a pull request.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+
+	// WebhookTriggerPullReqReviewerAdded fires when a reviewer is requested
+	// on a pull request, whether by a user or by CODEOWNERS auto-assignment.
+	WebhookTriggerPullReqReviewerAdded WebhookTrigger = "pullreq_reviewer_added"
+
+	// WebhookTriggerPullReqReviewerRemoved fires when a requested reviewer
+	// is removed from a pull request.
+	WebhookTriggerPullReqReviewerRemoved WebhookTrigger = "pullreq_reviewer_removed"
+)
+
+// WebhookTriggers lists every trigger a webhook can subscribe to, in the
+// order the admin UI should offer them for selection.
+var WebhookTriggers = []WebhookTrigger{
+	WebhookTriggerPullReqCreated,
+	WebhookTriggerPullReqReopened,
+	WebhookTriggerPullReqBranchUpdated,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqReviewerAdded,
+	WebhookTriggerPullReqReviewerRemoved,
+}
+
+// PullReqReviewDecision is the outcome of a submitted pull request review.
+type PullReqReviewDecision string
+
+const (
+	PullReqReviewDecisionApproved         PullReqReviewDecision = "approved"
+	PullReqReviewDecisionChangesRequested PullReqReviewDecision = "changes_requested"
+	PullReqReviewDecisionCommented        PullReqReviewDecision = "commented"
+)